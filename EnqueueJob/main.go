@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/google/uuid"
+
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/job"
+)
+
+var (
+	sess = session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	svc = dynamodb.New(sess)
+)
+
+type EnqueueRequest struct {
+	Type    job.Type `json:"type"`
+	Payload string   `json:"payload,omitempty"`
+}
+
+func enqueueJob(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	movieID := req.PathParameters["id"]
+	if movieID == "" {
+		return httpx.Error(http.StatusBadRequest, "Missing movie id in path"), nil
+	}
+
+	var in EnqueueRequest
+	if err := json.Unmarshal([]byte(req.Body), &in); err != nil {
+		return httpx.Error(http.StatusBadRequest, "Invalid payload"), nil
+	}
+
+	now := time.Now().UTC()
+	j := job.Job{
+		ID:        uuid.NewString(),
+		MovieID:   movieID,
+		Type:      in.Type,
+		Status:    job.StatusQueued,
+		Payload:   in.Payload,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	params := &dynamodb.PutItemInput{
+		TableName: aws.String(os.Getenv("JOBS_TABLE_NAME")),
+		Item: map[string]*dynamodb.AttributeValue{
+			"ID":        {S: aws.String(j.ID)},
+			"MovieID":   {S: aws.String(j.MovieID)},
+			"Type":      {S: aws.String(string(j.Type))},
+			"Status":    {S: aws.String(string(j.Status))},
+			"Payload":   {S: aws.String(j.Payload)},
+			"Attempts":  {N: aws.String("0")},
+			"CreatedAt": {S: aws.String(j.CreatedAt.Format(time.RFC3339))},
+			"UpdatedAt": {S: aws.String(j.UpdatedAt.Format(time.RFC3339))},
+		},
+	}
+
+	if _, err := svc.PutItem(params); err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error enqueuing job into DynamoDB"), nil
+	}
+
+	data, err := json.Marshal(j)
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error while decoding to string value"), nil
+	}
+
+	return httpx.Response(http.StatusCreated, data), nil
+}
+
+func main() {
+	lambda.Start(enqueueJob)
+}
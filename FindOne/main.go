@@ -3,66 +3,31 @@ package main
 import (
 	"encoding/json"
 	"net/http"
-	"os"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
 )
 
-type Movie struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}
+var repo = movies.NewRepository()
 
 func findOne(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	id := req.PathParameters["id"]
-
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-
-	svc := dynamodb.New(sess)
-
-	params := &dynamodb.GetItemInput{
-		TableName: aws.String(os.Getenv("TABLE_NAME")),
-		Key: map[string]*dynamodb.AttributeValue{
-			"ID": {
-				S: aws.String(id),
-			},
-		},
+	movie, err := repo.FindOne(req.PathParameters["id"])
+	if err == movies.ErrNotFound {
+		return httpx.Error(http.StatusNotFound, "Movie not found with the ID provided"), nil
 	}
-
-	res, err := svc.GetItem(params)
 	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       "Error while getting item DynamoDB",
-		}, nil
-	}
-
-	movie := Movie{
-		ID:   *res.Item["ID"].S,
-		Name: *res.Item["Name"].S,
+		return httpx.Error(http.StatusInternalServerError, "Error while getting item DynamoDB"), nil
 	}
 
 	data, err := json.Marshal(movie)
 	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       "Error while decoding to string value",
-		}, nil
+		return httpx.Error(http.StatusInternalServerError, "Error while decoding to string value"), nil
 	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusOK,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: string(data),
-	}, nil
+	return httpx.Response(http.StatusOK, data), nil
 }
 
 func main() {
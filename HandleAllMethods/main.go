@@ -4,32 +4,25 @@ import (
 	"encoding/json"
 	"math"
 	"net/http"
-	"os"
 	"sort"
 	"strconv"
 
-	"github.com/aws/aws-sdk-go/aws"
-
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/Jserrano27/aws-moviesapi/auth"
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
 )
 
-type Movie struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}
+const moviesWriteRole = "movies:write"
 
-type PaginatedMovie struct {
-	Movie      []Movie `json:"data"`
-	ActualPage int     `json:"actual_page"`
-	TotalPages int     `json:"total_pages"`
-}
+var repo = movies.NewRepository()
 
-type FeedbackResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+type PaginatedMovie struct {
+	Movie      []movies.Movie `json:"data"`
+	ActualPage int            `json:"actual_page"`
+	TotalPages int            `json:"total_pages"`
 }
 
 func main() {
@@ -44,353 +37,113 @@ func handleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 		}
 		return findOneMovie(req)
 	case http.MethodPost:
-		return insertMovie(req)
+		return auth.Require(insertMovie, moviesWriteRole)(req)
 	case http.MethodPut:
-		return updateMovie(req)
+		return auth.Require(updateMovie, moviesWriteRole)(req)
 	case http.MethodDelete:
-		return deleteMovie(req)
+		return auth.Require(deleteMovie, moviesWriteRole)(req)
 	default:
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusMethodNotAllowed,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Unsupported HTTP method")),
-		}, nil
+		return httpx.Error(http.StatusMethodNotAllowed, "Unsupported HTTP method"), nil
 	}
 }
 
 func findAllMovies(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-
-	svc := dynamodb.New(sess)
-
-	params := &dynamodb.ScanInput{
-		TableName: aws.String(os.Getenv("TABLE_NAME")),
-	}
-
-	res, err := svc.Scan(params)
+	all, err := repo.FindAll()
 	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Error while scanning DynamoDB")),
-		}, nil
-	}
-
-	movies := make([]Movie, 0)
-	for _, item := range res.Items {
-		movies = append(movies, Movie{
-			ID:   *item["ID"].S,
-			Name: *item["Name"].S,
-		})
+		return httpx.Error(http.StatusInternalServerError, "Error while scanning DynamoDB"), nil
 	}
 
-	sort.Slice(movies, func(i, j int) bool {
-		return movies[i].ID < movies[j].ID
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
 	})
 
 	page := req.QueryStringParameters["page"]
-
 	if page == "" {
 		page = "1"
 	}
 
 	pageNum, err := strconv.Atoi(page)
 	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Only numbers accepted in page query string")),
-		}, nil
-
+		return httpx.Error(http.StatusInternalServerError, "Only numbers accepted in page query string"), nil
 	}
 
-	paginated, totalPages := paginateMovies(movies, pageNum, 3)
+	paginated, totalPages := paginateMovies(all, pageNum, 3)
 
 	if pageNum > totalPages {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("The requested page exceeds the total of pages")),
-		}, nil
+		return httpx.Error(http.StatusBadRequest, "The requested page exceeds the total of pages"), nil
 	}
 
-	pagedMovies := PaginatedMovie{
+	data, err := json.Marshal(PaginatedMovie{
 		Movie:      paginated,
 		ActualPage: pageNum,
 		TotalPages: totalPages,
-	}
-
-	data, err := json.Marshal(pagedMovies)
+	})
 	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Error while decoding to string value")),
-		}, nil
+		return httpx.Error(http.StatusInternalServerError, "Error while decoding to string value"), nil
 	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusOK,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: string(data),
-	}, nil
+	return httpx.Response(http.StatusOK, data), nil
 }
 
 func findOneMovie(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	id := req.PathParameters["id"]
-
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-
-	svc := dynamodb.New(sess)
-
-	params := &dynamodb.GetItemInput{
-		TableName: aws.String(os.Getenv("TABLE_NAME")),
-		Key: map[string]*dynamodb.AttributeValue{
-			"ID": {
-				S: aws.String(id),
-			},
-		},
+	movie, err := repo.FindOne(req.PathParameters["id"])
+	if err == movies.ErrNotFound {
+		return httpx.Error(http.StatusNotFound, "Movie not found with the ID provided"), nil
 	}
-
-	res, err := svc.GetItem(params)
 	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Error while getting item DynamoDB")),
-		}, nil
-	}
-
-	if len(res.Item) == 0 {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusNotFound,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Movie not found with the ID provided")),
-		}, nil
-	}
-
-	movie := Movie{
-		ID:   *res.Item["ID"].S,
-		Name: *res.Item["Name"].S,
+		return httpx.Error(http.StatusInternalServerError, "Error while getting item DynamoDB"), nil
 	}
 
 	data, err := json.Marshal(movie)
 	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Error while decoding to string value")),
-		}, nil
+		return httpx.Error(http.StatusInternalServerError, "Error while decoding to string value"), nil
 	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusOK,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: string(data),
-	}, nil
+	return httpx.Response(http.StatusOK, data), nil
 }
 
 func insertMovie(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	var movie Movie
-	err := json.Unmarshal([]byte(req.Body), &movie)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Invalid payload")),
-		}, nil
+	var movie movies.Movie
+	if err := json.Unmarshal([]byte(req.Body), &movie); err != nil {
+		return httpx.Error(http.StatusBadRequest, "Invalid payload"), nil
 	}
 
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-
-	svc := dynamodb.New(sess)
-
-	params := &dynamodb.PutItemInput{
-		TableName: aws.String(os.Getenv("TABLE_NAME")),
-		Item: map[string]*dynamodb.AttributeValue{
-			"ID": {
-				S: aws.String(movie.ID),
-			},
-			"Name": {
-				S: aws.String(movie.Name),
-			},
-		},
-	}
-
-	_, err = svc.PutItem(params)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Error inserting movie into DynamoDB")),
-		}, nil
+	if err := repo.Put(movie); err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error inserting movie into DynamoDB"), nil
 	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: 200,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: string(jsonSuccessResponse("Movie inserted successfully")),
-	}, nil
+	return httpx.Success(http.StatusOK, "Movie inserted successfully"), nil
 }
 
 func updateMovie(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	var movie Movie
-	err := json.Unmarshal([]byte(req.Body), &movie)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Invalid payload")),
-		}, nil
-	}
-
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-
-	svc := dynamodb.New(sess)
-
-	params := &dynamodb.UpdateItemInput{
-		ExpressionAttributeNames: map[string]*string{
-			"#NAME": aws.String("Name"),
-		},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":name": {
-				S: aws.String(movie.Name),
-			},
-		},
-		Key: map[string]*dynamodb.AttributeValue{
-			"ID": {
-				S: aws.String(movie.ID),
-			},
-		},
-		TableName:        aws.String(os.Getenv("TABLE_NAME")),
-		UpdateExpression: aws.String("SET #NAME = :name"),
+	var movie movies.Movie
+	if err := json.Unmarshal([]byte(req.Body), &movie); err != nil {
+		return httpx.Error(http.StatusBadRequest, "Invalid payload"), nil
 	}
 
-	_, err = svc.UpdateItem(params)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Error updating movie from DynamoDB")),
-		}, nil
+	if err := repo.Update(movie); err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error updating movie from DynamoDB"), nil
 	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: 200,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: string(jsonSuccessResponse("Movie updated successfully")),
-	}, nil
+	return httpx.Success(http.StatusOK, "Movie updated successfully"), nil
 }
 
 func deleteMovie(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	var movie Movie
-	err := json.Unmarshal([]byte(req.Body), &movie)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Invalid payload")),
-		}, nil
+	var movie movies.Movie
+	if err := json.Unmarshal([]byte(req.Body), &movie); err != nil {
+		return httpx.Error(http.StatusBadRequest, "Invalid payload"), nil
 	}
 
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-
-	svc := dynamodb.New(sess)
-
-	params := &dynamodb.DeleteItemInput{
-		TableName: aws.String(os.Getenv("TABLE_NAME")),
-		Key: map[string]*dynamodb.AttributeValue{
-			"ID": {
-				S: aws.String(movie.ID),
-			},
-		},
-	}
-
-	_, err = svc.DeleteItem(params)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: string(jsonErrorResponse("Error deleting movie into DynamoDB")),
-		}, nil
+	if err := repo.Delete(movie.ID); err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error deleting movie into DynamoDB"), nil
 	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: 200,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: string(jsonSuccessResponse("Movie deleted successfully")),
-	}, nil
+	return httpx.Success(http.StatusOK, "Movie deleted successfully"), nil
 }
 
-func jsonErrorResponse(errMessage string) []byte {
-	res := FeedbackResponse{
-		Success: false,
-		Message: errMessage,
-	}
-	jsonResponse, _ := json.Marshal(res)
-	return jsonResponse
-}
-
-func jsonSuccessResponse(successMessage string) []byte {
-	res := FeedbackResponse{
-		Success: true,
-		Message: successMessage,
-	}
-	jsonResponse, _ := json.Marshal(res)
-	return jsonResponse
-}
-
-func paginateMovies(movies []Movie, pageNum, pageSize int) ([]Movie, int) {
+func paginateMovies(all []movies.Movie, pageNum, pageSize int) ([]movies.Movie, int) {
 	pageNum--
-	sliceLength := len(movies)
+	sliceLength := len(all)
 	totalPages := int(math.Ceil(float64(sliceLength) / float64(pageSize)))
 	start := pageNum * pageSize
 	end := start + pageSize
@@ -403,7 +156,5 @@ func paginateMovies(movies []Movie, pageNum, pageSize int) ([]Movie, int) {
 		end = sliceLength
 	}
 
-	pagedMovies := movies[start:end]
-
-	return pagedMovies, totalPages
+	return all[start:end], totalPages
 }
@@ -3,66 +3,30 @@ package main
 import (
 	"encoding/json"
 	"net/http"
-	"os"
-
-	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/Jserrano27/aws-moviesapi/auth"
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
 )
 
-type Movie struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}
+var repo = movies.NewRepository()
 
 func insert(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	var movie Movie
-	err := json.Unmarshal([]byte(req.Body), &movie)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Body:       "Invalid payload: " + err.Error(),
-		}, nil
-	}
-
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-
-	svc := dynamodb.New(sess)
-
-	params := &dynamodb.PutItemInput{
-		TableName: aws.String(os.Getenv("TABLE_NAME")),
-		Item: map[string]*dynamodb.AttributeValue{
-			"ID": {
-				S: aws.String(movie.ID),
-			},
-			"Name": {
-				S: aws.String(movie.Name),
-			},
-		},
+	var movie movies.Movie
+	if err := json.Unmarshal([]byte(req.Body), &movie); err != nil {
+		return httpx.Error(http.StatusBadRequest, "Invalid payload: "+err.Error()), nil
 	}
 
-	_, err = svc.PutItem(params)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       "Error inserting movie into DynamoDB: " + err.Error(),
-		}, nil
+	if err := repo.Put(movie); err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error inserting movie into DynamoDB: "+err.Error()), nil
 	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: 200,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: "Movie inserted successfully",
-	}, nil
+	return httpx.Success(http.StatusOK, "Movie inserted successfully"), nil
 }
 
 func main() {
-	lambda.Start(insert)
+	lambda.Start(auth.Require(insert, "movies:write"))
 }
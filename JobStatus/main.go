@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/job"
+)
+
+var (
+	sess = session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	svc = dynamodb.New(sess)
+)
+
+func jobStatus(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	id := req.PathParameters["id"]
+
+	params := &dynamodb.GetItemInput{
+		TableName: aws.String(os.Getenv("JOBS_TABLE_NAME")),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {
+				S: aws.String(id),
+			},
+		},
+	}
+
+	res, err := svc.GetItem(params)
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error while getting item DynamoDB"), nil
+	}
+
+	if len(res.Item) == 0 {
+		return httpx.Error(http.StatusNotFound, "Job not found with the ID provided"), nil
+	}
+
+	attempts, _ := strconv.Atoi(aws.StringValue(res.Item["Attempts"].N))
+
+	j := job.Job{
+		ID:       id,
+		MovieID:  aws.StringValue(res.Item["MovieID"].S),
+		Type:     job.Type(aws.StringValue(res.Item["Type"].S)),
+		Status:   job.Status(aws.StringValue(res.Item["Status"].S)),
+		Attempts: attempts,
+	}
+
+	if v, ok := res.Item["Payload"]; ok {
+		j.Payload = aws.StringValue(v.S)
+	}
+	if v, ok := res.Item["Result"]; ok {
+		j.Result = aws.StringValue(v.S)
+	}
+	if v, ok := res.Item["CreatedAt"]; ok {
+		j.CreatedAt, _ = time.Parse(time.RFC3339, aws.StringValue(v.S))
+	}
+	if v, ok := res.Item["UpdatedAt"]; ok {
+		j.UpdatedAt, _ = time.Parse(time.RFC3339, aws.StringValue(v.S))
+	}
+
+	data, err := json.Marshal(j)
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error while decoding to string value"), nil
+	}
+
+	return httpx.Response(http.StatusOK, data), nil
+}
+
+func main() {
+	lambda.Start(jobStatus)
+}
@@ -0,0 +1,99 @@
+// Command Login issues JWTs for seeded users so they can call the
+// movies:write-gated endpoints behind auth.Require.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+)
+
+var (
+	sess = session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	svc = dynamodb.New(sess)
+)
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Token string `json:"token"`
+}
+
+type user struct {
+	Username     string
+	PasswordHash string
+	Roles        []string
+}
+
+func login(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var in LoginRequest
+	if err := json.Unmarshal([]byte(req.Body), &in); err != nil || in.Username == "" || in.Password == "" {
+		return httpx.Error(http.StatusBadRequest, "Invalid payload"), nil
+	}
+
+	res, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(os.Getenv("USERS_TABLE_NAME")),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Username": {
+				S: aws.String(in.Username),
+			},
+		},
+	})
+	if err != nil || len(res.Item) == 0 {
+		return httpx.Error(http.StatusUnauthorized, "Invalid username or password"), nil
+	}
+
+	u := user{
+		Username:     aws.StringValue(res.Item["Username"].S),
+		PasswordHash: aws.StringValue(res.Item["PasswordHash"].S),
+	}
+	if v, ok := res.Item["Roles"]; ok {
+		for _, r := range v.SS {
+			u.Roles = append(u.Roles, aws.StringValue(r))
+		}
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(in.Password)); err != nil {
+		return httpx.Error(http.StatusUnauthorized, "Invalid username or password"), nil
+	}
+
+	now := time.Now().UTC()
+	claims := jwt.MapClaims{
+		"sub":   u.Username,
+		"roles": u.Roles,
+		"iat":   now.Unix(),
+		"exp":   now.Add(24 * time.Hour).Unix(),
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(os.Getenv("JWT_SECRET")))
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error issuing token"), nil
+	}
+
+	data, err := json.Marshal(LoginResponse{Token: signed})
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error while decoding to string value"), nil
+	}
+
+	return httpx.Response(http.StatusOK, data), nil
+}
+
+func main() {
+	lambda.Start(login)
+}
@@ -0,0 +1,79 @@
+// Command MigrateMovies is a one-off Lambda that rewrites every item in the
+// Movies table from the legacy {ID, Name} shape into the extended Movie
+// schema, so existing data keeps working once the new fields are in use.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
+)
+
+type legacyMovie struct {
+	ID   string `json:"ID"`
+	Name string `json:"Name"`
+}
+
+var repo = movies.NewRepository()
+
+func migrate() error {
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+
+	svc := dynamodb.New(sess)
+	tableName := aws.String(os.Getenv("TABLE_NAME"))
+
+	var lastKey map[string]*dynamodb.AttributeValue
+
+	for {
+		res, err := svc.Scan(&dynamodb.ScanInput{
+			TableName:         tableName,
+			ExclusiveStartKey: lastKey,
+		})
+		if err != nil {
+			return fmt.Errorf("scanning movies table: %w", err)
+		}
+
+		for _, rawItem := range res.Items {
+			// Already-migrated items have a Title field; legacy items don't.
+			if _, ok := rawItem["Title"]; ok {
+				continue
+			}
+
+			var legacy legacyMovie
+			if err := dynamodbattribute.UnmarshalMap(rawItem, &legacy); err != nil {
+				return fmt.Errorf("unmarshaling legacy movie: %w", err)
+			}
+
+			// Put stamps Bucket and UpdatedAt itself, so the migrated item
+			// is picked up by the NameIndex GSI just like any other write.
+			movie := movies.Movie{
+				ID:    legacy.ID,
+				Title: legacy.Name,
+			}
+
+			if err := repo.Put(movie); err != nil {
+				return fmt.Errorf("writing migrated movie %s: %w", legacy.ID, err)
+			}
+		}
+
+		if res.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = res.LastEvaluatedKey
+	}
+
+	return nil
+}
+
+func main() {
+	lambda.Start(migrate)
+}
@@ -0,0 +1,284 @@
+// Command SearchMovies backs GET /movies/search. It replaces the old
+// full-table Scan-and-paginate-in-memory approach with DynamoDB Query calls
+// against dedicated GSIs, while still accepting the legacy ?page= parameter
+// via the paginator package.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
+	"github.com/Jserrano27/aws-moviesapi/paginator"
+)
+
+const nameIndexBucket = "MOVIE"
+
+var (
+	sess = session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	svc = dynamodb.New(sess)
+)
+
+type SearchResponse struct {
+	Data       []movies.Movie `json:"data"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+func searchMovies(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	q := req.QueryStringParameters["q"]
+	tag := req.QueryStringParameters["tag"]
+	year := req.QueryStringParameters["year"]
+	sortOrder := req.QueryStringParameters["sort"]
+	page := req.QueryStringParameters["page"]
+	cursorToken := req.QueryStringParameters["cursor"]
+
+	startKey, err := resolveStartKey(q, tag, year, sortOrder, page, cursorToken)
+	if errors.Is(err, errPageNotCached) {
+		return httpx.Error(http.StatusBadRequest, errPageNotCached.Error()), nil
+	}
+	if err != nil {
+		return httpx.Error(http.StatusBadRequest, "Invalid cursor or page"), nil
+	}
+
+	var yearNum int
+	if year != "" {
+		y, convErr := strconv.Atoi(year)
+		if convErr != nil {
+			return httpx.Error(http.StatusBadRequest, "year must be numeric"), nil
+		}
+		yearNum = y
+	}
+
+	var (
+		results []movies.Movie
+		lastKey paginator.Cursor
+	)
+
+	if tag != "" {
+		// Tag searches go through MovieTags' TagIndex GSI, which is
+		// partitioned by TagID, instead of the NameIndex Query below: that
+		// index shares one partition (Bucket="MOVIE") across every movie,
+		// so filtering it by tag would still mean reading the whole table
+		// before throwing most of it away. TagIndex bounds the read to
+		// movies that actually carry the tag.
+		matches, tagLastKey, err := queryByTag(svc, tag, sortOrder, startKey)
+		if err != nil {
+			return httpx.Error(http.StatusInternalServerError, "Error querying TagIndex"), nil
+		}
+
+		for _, movie := range matches {
+			if q != "" && !strings.HasPrefix(strings.ToLower(movie.Title), strings.ToLower(q)) {
+				continue
+			}
+			if year != "" && movie.ReleaseYear != yearNum {
+				continue
+			}
+			results = append(results, movie)
+		}
+		sort.Slice(results, func(i, j int) bool {
+			if sortOrder == "desc" {
+				return results[i].Title > results[j].Title
+			}
+			return results[i].Title < results[j].Title
+		})
+
+		lastKey = tagLastKey
+	} else {
+		keyCond := expression.Key("Bucket").Equal(expression.Value(nameIndexBucket))
+		if q != "" {
+			keyCond = keyCond.And(expression.Key("Title").BeginsWith(q))
+		}
+
+		builder := expression.NewBuilder().WithKeyCondition(keyCond)
+
+		if year != "" {
+			builder = builder.WithFilter(expression.Name("ReleaseYear").Equal(expression.Value(yearNum)))
+		}
+
+		expr, err := builder.Build()
+		if err != nil {
+			return httpx.Error(http.StatusInternalServerError, "Error building search expression"), nil
+		}
+
+		params := &dynamodb.QueryInput{
+			TableName:                 aws.String(os.Getenv("TABLE_NAME")),
+			IndexName:                 aws.String("NameIndex"),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         startKey,
+			ScanIndexForward:          aws.Bool(sortOrder != "desc"),
+		}
+
+		res, err := svc.Query(params)
+		if err != nil {
+			return httpx.Error(http.StatusInternalServerError, "Error querying NameIndex"), nil
+		}
+
+		results = make([]movies.Movie, 0, len(res.Items))
+		for _, rawItem := range res.Items {
+			var movie movies.Movie
+			if err := dynamodbattribute.UnmarshalMap(rawItem, &movie); err != nil {
+				return httpx.Error(http.StatusInternalServerError, "Error while decoding item from DynamoDB"), nil
+			}
+			results = append(results, movie)
+		}
+
+		lastKey = res.LastEvaluatedKey
+	}
+
+	nextCursor, err := paginator.Encode(lastKey)
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error encoding pagination cursor"), nil
+	}
+
+	if page != "" {
+		if pageNum, convErr := strconv.Atoi(page); convErr == nil {
+			paginator.Remember(querySignature(q, tag, year, sortOrder), pageNum, lastKey)
+		}
+	}
+
+	data, err := json.Marshal(SearchResponse{Data: results, NextCursor: nextCursor})
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error while decoding to string value"), nil
+	}
+
+	return httpx.Response(http.StatusOK, data), nil
+}
+
+// errPageNotCached is returned when the legacy ?page= API is asked for a
+// page this Lambda instance never recorded a cursor for. paginator's cache
+// is process-local, so this can happen on a cold start or a different warm
+// instance; callers should surface it rather than silently resume from the
+// beginning and mislabel those results as the requested page.
+var errPageNotCached = errors.New("no cached cursor for requested page; retry with the cursor from the previous response")
+
+// resolveStartKey prefers an explicit cursor token, falling back to the
+// legacy page-number API by looking up the cursor recorded for the previous
+// page of this same query.
+func resolveStartKey(q, tag, year, sortOrder, page, cursorToken string) (paginator.Cursor, error) {
+	if cursorToken != "" {
+		return paginator.Decode(cursorToken)
+	}
+
+	if page == "" {
+		return nil, nil
+	}
+
+	pageNum, err := strconv.Atoi(page)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, ok := paginator.Lookup(querySignature(q, tag, year, sortOrder), pageNum)
+	if !ok {
+		return nil, errPageNotCached
+	}
+
+	return cursor, nil
+}
+
+func querySignature(q, tag, year, sortOrder string) string {
+	return q + "|" + tag + "|" + year + "|" + sortOrder
+}
+
+// queryByTag looks up the movies tagged with tag via MovieTags' TagIndex GSI
+// (partitioned by TagID), then fetches those movies by ID. This is bounded
+// by how many movies carry the tag, unlike filtering the NameIndex Query
+// which still has to read every movie in the table first.
+func queryByTag(svc *dynamodb.DynamoDB, tag, sortOrder string, startKey paginator.Cursor) ([]movies.Movie, paginator.Cursor, error) {
+	expr, err := expression.NewBuilder().
+		WithKeyCondition(expression.Key("TagID").Equal(expression.Value(tag))).
+		Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := svc.Query(&dynamodb.QueryInput{
+		TableName:                 aws.String(os.Getenv("MOVIE_TAGS_TABLE_NAME")),
+		IndexName:                 aws.String("TagIndex"),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ExclusiveStartKey:         startKey,
+		ScanIndexForward:          aws.Bool(sortOrder != "desc"),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	movieIDs := make([]string, 0, len(res.Items))
+	for _, rawItem := range res.Items {
+		if v, ok := rawItem["MovieID"]; ok {
+			movieIDs = append(movieIDs, aws.StringValue(v.S))
+		}
+	}
+
+	matches, err := batchGetMovies(svc, movieIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return matches, res.LastEvaluatedKey, nil
+}
+
+// batchGetMovies fetches movies by ID in batches of up to 100, the limit
+// BatchGetItem enforces per request.
+func batchGetMovies(svc *dynamodb.DynamoDB, ids []string) ([]movies.Movie, error) {
+	tableName := os.Getenv("TABLE_NAME")
+
+	var results []movies.Movie
+	for start := 0; start < len(ids); start += 100 {
+		end := start + 100
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		keys := make([]map[string]*dynamodb.AttributeValue, 0, end-start)
+		for _, id := range ids[start:end] {
+			keys = append(keys, map[string]*dynamodb.AttributeValue{
+				"ID": {S: aws.String(id)},
+			})
+		}
+
+		res, err := svc.BatchGetItem(&dynamodb.BatchGetItemInput{
+			RequestItems: map[string]*dynamodb.KeysAndAttributes{
+				tableName: {Keys: keys},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rawItem := range res.Responses[tableName] {
+			var movie movies.Movie
+			if err := dynamodbattribute.UnmarshalMap(rawItem, &movie); err != nil {
+				return nil, err
+			}
+			results = append(results, movie)
+		}
+	}
+
+	return results, nil
+}
+
+func main() {
+	lambda.Start(searchMovies)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/Jserrano27/aws-moviesapi/auth"
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
+)
+
+type TagRequest struct {
+	Tag string `json:"tag"`
+}
+
+// MovieTag is a single row in the MovieTags table, letting movies be looked
+// up by tag without a table scan.
+type MovieTag struct {
+	MovieID string `json:"movie_id"`
+	TagID   string `json:"tag_id"`
+}
+
+var (
+	repo = movies.NewRepository()
+	sess = session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	svc = dynamodb.New(sess)
+)
+
+func tagMovie(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	movieID := req.PathParameters["id"]
+	if movieID == "" {
+		return httpx.Error(http.StatusBadRequest, "Missing movie id in path"), nil
+	}
+
+	var in TagRequest
+	if err := json.Unmarshal([]byte(req.Body), &in); err != nil || in.Tag == "" {
+		return httpx.Error(http.StatusBadRequest, "Invalid payload"), nil
+	}
+
+	movie, err := repo.FindOne(movieID)
+	if err == movies.ErrNotFound {
+		return httpx.Error(http.StatusNotFound, "Movie not found with the ID provided"), nil
+	}
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error while getting item DynamoDB"), nil
+	}
+
+	// Keep the Movie item's own Tags slice in sync with the MovieTags rows
+	// TagMovie/UntagMovie manage here.
+	if !hasTag(movie.Tags, in.Tag) {
+		movie.Tags = append(movie.Tags, in.Tag)
+		if err := repo.Update(movie); err != nil {
+			return httpx.Error(http.StatusInternalServerError, "Error updating movie tags in DynamoDB"), nil
+		}
+	}
+
+	if _, err := svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(os.Getenv("MOVIE_TAGS_TABLE_NAME")),
+		Item: map[string]*dynamodb.AttributeValue{
+			"MovieID": {S: aws.String(movieID)},
+			"TagID":   {S: aws.String(in.Tag)},
+		},
+	}); err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error tagging movie in DynamoDB"), nil
+	}
+
+	return httpx.Success(http.StatusOK, "Movie tagged successfully"), nil
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	lambda.Start(auth.Require(tagMovie, "movies:write"))
+}
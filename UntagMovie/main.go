@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/Jserrano27/aws-moviesapi/auth"
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
+)
+
+var (
+	repo = movies.NewRepository()
+	sess = session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	svc = dynamodb.New(sess)
+)
+
+func untagMovie(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	movieID := req.PathParameters["id"]
+	tag := req.PathParameters["tag"]
+	if movieID == "" || tag == "" {
+		return httpx.Error(http.StatusBadRequest, "Missing movie id or tag in path"), nil
+	}
+
+	movie, err := repo.FindOne(movieID)
+	if err == movies.ErrNotFound {
+		return httpx.Error(http.StatusNotFound, "Movie not found with the ID provided"), nil
+	}
+	if err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error while getting item DynamoDB"), nil
+	}
+
+	// Keep the Movie item's own Tags slice in sync with the MovieTags rows
+	// TagMovie/UntagMovie manage here.
+	if updated, changed := withoutTag(movie.Tags, tag); changed {
+		movie.Tags = updated
+		if err := repo.Update(movie); err != nil {
+			return httpx.Error(http.StatusInternalServerError, "Error updating movie tags in DynamoDB"), nil
+		}
+	}
+
+	if _, err := svc.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(os.Getenv("MOVIE_TAGS_TABLE_NAME")),
+		Key: map[string]*dynamodb.AttributeValue{
+			"MovieID": {S: aws.String(movieID)},
+			"TagID":   {S: aws.String(tag)},
+		},
+	}); err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error untagging movie in DynamoDB"), nil
+	}
+
+	return httpx.Success(http.StatusOK, "Movie untagged successfully"), nil
+}
+
+func withoutTag(tags []string, tag string) ([]string, bool) {
+	filtered := make([]string, 0, len(tags))
+	changed := false
+	for _, t := range tags {
+		if t == tag {
+			changed = true
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered, changed
+}
+
+func main() {
+	lambda.Start(auth.Require(untagMovie, "movies:write"))
+}
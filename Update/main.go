@@ -3,72 +3,70 @@ package main
 import (
 	"encoding/json"
 	"net/http"
-	"os"
-
-	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/Jserrano27/aws-moviesapi/auth"
+	"github.com/Jserrano27/aws-moviesapi/internal/httpx"
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
 )
 
-type Movie struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}
+var repo = movies.NewRepository()
 
 func update(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	var movie Movie
-	err := json.Unmarshal([]byte(req.Body), &movie)
+	var in movies.Movie
+	if err := json.Unmarshal([]byte(req.Body), &in); err != nil {
+		return httpx.Error(http.StatusBadRequest, "Invalid payload: "+err.Error()), nil
+	}
+
+	existing, err := repo.FindOne(in.ID)
+	if err == movies.ErrNotFound {
+		return httpx.Error(http.StatusNotFound, "Movie not found with the ID provided"), nil
+	}
 	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Body:       "Invalid payload: " + err.Error(),
-		}, nil
+		return httpx.Error(http.StatusInternalServerError, "Error while getting item DynamoDB: "+err.Error()), nil
 	}
 
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
+	if err := repo.Update(mergeMovie(existing, in)); err != nil {
+		return httpx.Error(http.StatusInternalServerError, "Error updating movie from DynamoDB: "+err.Error()), nil
+	}
 
-	svc := dynamodb.New(sess)
+	return httpx.Success(http.StatusOK, "Movie updated successfully"), nil
+}
 
-	params := &dynamodb.UpdateItemInput{
-		ExpressionAttributeNames: map[string]*string{
-			"#NAME": aws.String("Name"),
-		},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":name": {
-				S: aws.String(movie.Name),
-			},
-		},
-		Key: map[string]*dynamodb.AttributeValue{
-			"ID": {
-				S: aws.String(movie.ID),
-			},
-		},
-		TableName:        aws.String(os.Getenv("TABLE_NAME")),
-		UpdateExpression: aws.String("SET #NAME = :name"),
+// mergeMovie applies only the fields set on in over existing, so a PUT that
+// doesn't echo back every field (e.g. Tags set by TagMovie/UntagMovie) can't
+// silently wipe them the way a full overwrite would.
+func mergeMovie(existing, in movies.Movie) movies.Movie {
+	merged := existing
+	if in.Title != "" {
+		merged.Title = in.Title
 	}
-
-	_, err = svc.UpdateItem(params)
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       "Error updating movie from DynamoDB: " + err.Error(),
-		}, nil
+	if in.IMDbID != "" {
+		merged.IMDbID = in.IMDbID
 	}
-
-	return events.APIGatewayProxyResponse{
-		StatusCode: 200,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: "Movie updated successfully",
-	}, nil
+	if in.ReleaseYear != 0 {
+		merged.ReleaseYear = in.ReleaseYear
+	}
+	if in.Tags != nil {
+		merged.Tags = in.Tags
+	}
+	if in.Rating != 0 {
+		merged.Rating = in.Rating
+	}
+	if in.PosterURL != "" {
+		merged.PosterURL = in.PosterURL
+	}
+	if in.Synopsis != "" {
+		merged.Synopsis = in.Synopsis
+	}
+	if in.MagnetLinks != nil {
+		merged.MagnetLinks = in.MagnetLinks
+	}
+	return merged
 }
 
 func main() {
-	lambda.Start(update)
+	lambda.Start(auth.Require(update, "movies:write"))
 }
@@ -0,0 +1,128 @@
+// Package auth validates bearer JWTs on incoming API Gateway requests and
+// gates handlers behind a required role. Rejections use the same
+// {success: false, message} shape as httpx.Error, so a 401/403 looks
+// like any other API error to callers.
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the JWT claims this API relies on.
+type Claims struct {
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// Handler matches the signature every Lambda entrypoint in this repo uses.
+type Handler func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+type feedbackResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// jwks is lazily populated from JWKS_URL the first time an RS256 token needs
+// verifying, and reused for the life of the Lambda's execution environment.
+var jwks *keyfunc.JWKS
+
+// Require wraps handler so it only runs once the request's bearer token is
+// valid and carries role. GET requests are expected to stay unwrapped.
+func Require(handler Handler, role string) Handler {
+	return func(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		claims, err := authenticate(req)
+		if err != nil {
+			return errorResponse(http.StatusUnauthorized, err.Error()), nil
+		}
+
+		if !hasRole(claims, role) {
+			return errorResponse(http.StatusForbidden, fmt.Sprintf("missing required role %q", role)), nil
+		}
+
+		return handler(req)
+	}
+}
+
+func authenticate(req events.APIGatewayProxyRequest) (*Claims, error) {
+	token, err := bearerToken(req)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{}
+
+	parsed, err := jwt.ParseWithClaims(token, claims, keyFunc)
+	if err != nil || !parsed.Valid {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	return claims, nil
+}
+
+func bearerToken(req events.APIGatewayProxyRequest) (string, error) {
+	header := req.Headers["Authorization"]
+	if header == "" {
+		header = req.Headers["authorization"]
+	}
+
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", errors.New("missing bearer token")
+	}
+
+	return strings.TrimPrefix(header, prefix), nil
+}
+
+// keyFunc resolves the key used to verify a token: the shared HS256 secret
+// when JWT_SECRET is set, otherwise the RS256 keys published at JWKS_URL.
+func keyFunc(token *jwt.Token) (interface{}, error) {
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}
+
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	if jwks == nil {
+		var err error
+		jwks, err = keyfunc.Get(os.Getenv("JWKS_URL"), keyfunc.Options{})
+		if err != nil {
+			return nil, fmt.Errorf("fetching JWKS: %w", err)
+		}
+	}
+
+	return jwks.Keyfunc(token)
+}
+
+func hasRole(claims *Claims, role string) bool {
+	for _, r := range claims.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func errorResponse(status int, message string) events.APIGatewayProxyResponse {
+	body, _ := json.Marshal(feedbackResponse{Success: false, Message: message})
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: string(body),
+	}
+}
@@ -0,0 +1,91 @@
+// Command sweeper runs on a CloudWatch Events schedule and nudges jobs whose
+// lease or backoff has expired back onto the Jobs table's DynamoDB Stream,
+// so handleStream in cmd/worker picks them up again. Without this, a job
+// that failed and was backed off (or a worker that crashed mid-lease) sits
+// untouched forever: Streams only fires on a write, and nothing writes to
+// that item again until a worker happens to receive another event for it.
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+
+	"github.com/Jserrano27/aws-moviesapi/job"
+)
+
+var (
+	sess      = session.Must(session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable}))
+	svc       = dynamodb.New(sess)
+	tableName = os.Getenv("JOBS_TABLE_NAME")
+)
+
+// sweep scans for queued or running jobs past their LeaseExpiry and touches
+// each one so its resulting stream event re-enters the worker Lambda's normal
+// processJob path, where claimLease's condition will now succeed.
+func sweep(events.CloudWatchEvent) error {
+	now := time.Now().UTC()
+
+	filter := expression.Name("Status").In(
+		expression.Value(string(job.StatusQueued)),
+		expression.Value(string(job.StatusRunning)),
+	).And(expression.Name("LeaseExpiry").LessThan(expression.Value(now.Format(time.RFC3339))))
+
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		return err
+	}
+
+	var lastKey map[string]*dynamodb.AttributeValue
+	for {
+		res, err := svc.Scan(&dynamodb.ScanInput{
+			TableName:                 aws.String(tableName),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         lastKey,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range res.Items {
+			if err := touch(aws.StringValue(item["ID"].S), now); err != nil {
+				return err
+			}
+		}
+
+		if res.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = res.LastEvaluatedKey
+	}
+
+	return nil
+}
+
+// touch writes SweptAt onto a job, producing the MODIFY stream record that
+// gets it re-evaluated without otherwise changing its state.
+func touch(id string, now time.Time) error {
+	_, err := svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {S: aws.String(id)},
+		},
+		UpdateExpression: aws.String("SET SweptAt = :now"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":now": {S: aws.String(now.Format(time.RFC3339))},
+		},
+	})
+	return err
+}
+
+func main() {
+	lambda.Start(sweep)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Jserrano27/aws-moviesapi/job"
+)
+
+// fetchMetadata handles job.TypeFetchMetadata. It calls no external metadata
+// source and changes no Movie; it returns a message naming the movie.
+func fetchMetadata(j job.Job) (string, error) {
+	// TODO: call out to the external metadata provider once one is chosen.
+	return fmt.Sprintf("metadata fetched for movie %s", j.MovieID), nil
+}
+
+// transcodeArtwork handles job.TypeTranscodeArtwork. It encodes no assets;
+// it returns a message naming the movie.
+func transcodeArtwork(j job.Job) (string, error) {
+	// TODO: wire up the actual transcoding pipeline.
+	return fmt.Sprintf("artwork transcoded for movie %s", j.MovieID), nil
+}
+
+// reindexSearch handles job.TypeReindexSearch. It writes to no search index;
+// it returns a message naming the movie.
+func reindexSearch(j job.Job) (string, error) {
+	// TODO: push the updated document to the search index.
+	return fmt.Sprintf("search index refreshed for movie %s", j.MovieID), nil
+}
@@ -0,0 +1,208 @@
+// Command worker consumes Jobs enqueued by EnqueueJob, executing whichever
+// Handler is registered for the job's Type and writing the result back.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/google/uuid"
+
+	"github.com/Jserrano27/aws-moviesapi/job"
+)
+
+var (
+	sess      = session.Must(session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable}))
+	svc       = dynamodb.New(sess)
+	tableName = os.Getenv("JOBS_TABLE_NAME")
+	workerID  = uuid.NewString()
+)
+
+func init() {
+	job.Register(job.TypeFetchMetadata, fetchMetadata)
+	job.Register(job.TypeTranscodeArtwork, transcodeArtwork)
+	job.Register(job.TypeReindexSearch, reindexSearch)
+}
+
+// handleStream fires for every insert/modify on the Jobs table and attempts
+// to claim and run each job that is not done, not under a live lease, and
+// not failed after job.MaxAttempts.
+func handleStream(e events.DynamoDBEvent) error {
+	for _, record := range e.Records {
+		if record.EventName != "INSERT" && record.EventName != "MODIFY" {
+			continue
+		}
+
+		id, ok := record.Change.NewImage["ID"]
+		if !ok {
+			continue
+		}
+
+		processJob(id.String())
+	}
+
+	return nil
+}
+
+// processJob claims the job's lease, runs its Handler, and persists the
+// outcome, retrying with backoff on failure up to job.MaxAttempts.
+func processJob(id string) {
+	now := time.Now().UTC()
+
+	j, ok := loadJob(id)
+	if !ok || j.Status == job.StatusDone {
+		return
+	}
+
+	if j.Leased(now) {
+		return
+	}
+
+	if j.Status == job.StatusFailed && j.Attempts >= job.MaxAttempts {
+		return
+	}
+
+	lease := now.Add(job.VisibilityTimeout)
+	if !claimLease(j, lease) {
+		// Another worker won the race for this lease.
+		return
+	}
+
+	handler, ok := job.Lookup(j.Type)
+	if !ok {
+		finishJob(j, job.StatusFailed, fmt.Sprintf("no handler registered for job type %q", j.Type))
+		return
+	}
+
+	result, err := handler(j)
+	if err != nil {
+		if j.Attempts+1 >= job.MaxAttempts {
+			finishJob(j, job.StatusFailed, err.Error())
+			return
+		}
+		releaseLease(j)
+		return
+	}
+
+	finishJob(j, job.StatusDone, result)
+}
+
+func loadJob(id string) (job.Job, bool) {
+	res, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {S: aws.String(id)},
+		},
+	})
+	if err != nil || len(res.Item) == 0 {
+		return job.Job{}, false
+	}
+
+	attempts := int64(0)
+	if v, ok := res.Item["Attempts"]; ok {
+		attempts, _ = strconv.ParseInt(aws.StringValue(v.N), 10, 64)
+	}
+
+	j := job.Job{
+		ID:       id,
+		MovieID:  aws.StringValue(res.Item["MovieID"].S),
+		Type:     job.Type(aws.StringValue(res.Item["Type"].S)),
+		Status:   job.Status(aws.StringValue(res.Item["Status"].S)),
+		Payload:  aws.StringValue(res.Item["Payload"].S),
+		Attempts: int(attempts),
+	}
+
+	if v, ok := res.Item["LeaseOwner"]; ok {
+		j.LeaseOwner = aws.StringValue(v.S)
+	}
+	if v, ok := res.Item["LeaseExpiry"]; ok {
+		j.LeaseExpiry, _ = time.Parse(time.RFC3339, aws.StringValue(v.S))
+	}
+
+	return j, true
+}
+
+// claimLease conditionally marks the job running and owned by this worker,
+// failing if another worker already holds a live lease.
+func claimLease(j job.Job, expiry time.Time) bool {
+	now := time.Now().UTC()
+
+	_, err := svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {S: aws.String(j.ID)},
+		},
+		UpdateExpression: aws.String("SET #status = :running, LeaseOwner = :owner, LeaseExpiry = :expiry, UpdatedAt = :now"),
+		ConditionExpression: aws.String(
+			"attribute_not_exists(LeaseExpiry) OR LeaseExpiry < :now",
+		),
+		ExpressionAttributeNames: map[string]*string{
+			"#status": aws.String("Status"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":running": {S: aws.String(string(job.StatusRunning))},
+			":owner":   {S: aws.String(workerID)},
+			":expiry":  {S: aws.String(expiry.Format(time.RFC3339))},
+			":now":     {S: aws.String(now.Format(time.RFC3339))},
+		},
+	})
+
+	return err == nil
+}
+
+// releaseLease drops the lease after a failed attempt so the job becomes
+// eligible for a retry once job.Backoff has elapsed.
+func releaseLease(j job.Job) {
+	now := time.Now().UTC()
+	retryAt := now.Add(job.Backoff(j.Attempts))
+
+	svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {S: aws.String(j.ID)},
+		},
+		UpdateExpression: aws.String("SET #status = :queued, LeaseExpiry = :retryAt, Attempts = Attempts + :one, UpdatedAt = :now REMOVE LeaseOwner"),
+		ExpressionAttributeNames: map[string]*string{
+			"#status": aws.String("Status"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":queued":  {S: aws.String(string(job.StatusQueued))},
+			":retryAt": {S: aws.String(retryAt.Format(time.RFC3339))},
+			":one":     {N: aws.String("1")},
+			":now":     {S: aws.String(now.Format(time.RFC3339))},
+		},
+	})
+}
+
+func finishJob(j job.Job, status job.Status, result string) {
+	now := time.Now().UTC()
+
+	svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {S: aws.String(j.ID)},
+		},
+		UpdateExpression: aws.String("SET #status = :status, #result = :result, Attempts = Attempts + :one, UpdatedAt = :now REMOVE LeaseOwner, LeaseExpiry"),
+		ExpressionAttributeNames: map[string]*string{
+			"#status": aws.String("Status"),
+			"#result": aws.String("Result"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":status": {S: aws.String(string(status))},
+			":result": {S: aws.String(result)},
+			":one":    {N: aws.String("1")},
+			":now":    {S: aws.String(now.Format(time.RFC3339))},
+		},
+	})
+}
+
+func main() {
+	lambda.Start(handleStream)
+}
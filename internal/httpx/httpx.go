@@ -0,0 +1,54 @@
+// Package httpx holds the small response-shaping helpers shared across the
+// Movies API's Lambda entrypoints, so each one doesn't reimplement its own
+// copy of the {success, message} envelope.
+package httpx
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type feedbackResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// Response builds a JSON API Gateway response from an already-marshaled
+// body.
+func Response(status int, body []byte) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: string(body),
+	}
+}
+
+// Error builds a JSON error response in the {success: false, message}
+// shape used throughout this API.
+func Error(status int, message string) events.APIGatewayProxyResponse {
+	body, _ := json.Marshal(feedbackResponse{Success: false, Message: message})
+	return Response(status, body)
+}
+
+// Success builds a JSON success response in the {success: true, message}
+// shape used throughout this API.
+func Success(status int, message string) events.APIGatewayProxyResponse {
+	body, _ := json.Marshal(feedbackResponse{Success: true, Message: message})
+	return Response(status, body)
+}
+
+// ShiftPath splits the first segment off path, returning it along with the
+// remainder (still leading with "/"), for handlers that need to dispatch on
+// sub-paths API Gateway didn't already break out into PathParameters.
+func ShiftPath(path string) (head, tail string) {
+	path = strings.TrimPrefix(path, "/")
+	i := strings.Index(path, "/")
+	if i < 0 {
+		return path, "/"
+	}
+	return path[:i], path[i:]
+}
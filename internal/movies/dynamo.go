@@ -0,0 +1,116 @@
+package movies
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// dynamoRepository is the DynamoDB-backed Repository implementation used in
+// production.
+type dynamoRepository struct {
+	svc       *dynamodb.DynamoDB
+	tableName string
+}
+
+// NewRepository constructs a Repository backed by DynamoDB, reading the
+// table name from TABLE_NAME and, when set, the endpoint from
+// DYNAMODB_ENDPOINT. Intended to be called once at cold start and
+// reused across invocations, so the Lambda doesn't re-establish a session on
+// every request.
+func NewRepository() Repository {
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+
+	cfg := aws.NewConfig()
+	if endpoint := os.Getenv("DYNAMODB_ENDPOINT"); endpoint != "" {
+		cfg = cfg.WithEndpoint(endpoint)
+	}
+
+	return &dynamoRepository{
+		svc:       dynamodb.New(sess, cfg),
+		tableName: os.Getenv("TABLE_NAME"),
+	}
+}
+
+func (r *dynamoRepository) FindOne(id string) (Movie, error) {
+	res, err := r.svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {S: aws.String(id)},
+		},
+	})
+	if err != nil {
+		return Movie{}, err
+	}
+
+	if len(res.Item) == 0 {
+		return Movie{}, ErrNotFound
+	}
+
+	var movie Movie
+	if err := dynamodbattribute.UnmarshalMap(res.Item, &movie); err != nil {
+		return Movie{}, err
+	}
+
+	return movie, nil
+}
+
+func (r *dynamoRepository) FindAll() ([]Movie, error) {
+	res, err := r.svc.Scan(&dynamodb.ScanInput{
+		TableName: aws.String(r.tableName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	movies := make([]Movie, 0, len(res.Items))
+	for _, rawItem := range res.Items {
+		var movie Movie
+		if err := dynamodbattribute.UnmarshalMap(rawItem, &movie); err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+
+	return movies, nil
+}
+
+func (r *dynamoRepository) Put(movie Movie) error {
+	return r.put(movie)
+}
+
+func (r *dynamoRepository) Update(movie Movie) error {
+	return r.put(movie)
+}
+
+func (r *dynamoRepository) put(movie Movie) error {
+	movie.UpdatedAt = time.Now().UTC()
+	movie.Bucket = nameIndexBucket
+
+	item, err := dynamodbattribute.MarshalMap(movie)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      item,
+	})
+	return err
+}
+
+func (r *dynamoRepository) Delete(id string) error {
+	_, err := r.svc.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ID": {S: aws.String(id)},
+		},
+	})
+	return err
+}
@@ -0,0 +1,74 @@
+//go:build integration
+
+package movies_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/Jserrano27/aws-moviesapi/internal/movies"
+)
+
+// TestPutFindOneRoundTrip guards against Put and FindOne/Delete silently
+// disagreeing on attribute names. A unit test can't catch that class of bug:
+// dynamodbattribute.MarshalMap never errors on a mistagged field, it just
+// writes a different attribute than FindOne/Delete read by, and the
+// resulting PutItem is only rejected once a real table with the partition
+// key declared enforces it. Run with `go test -tags=integration ./...`
+// against a local DynamoDB (e.g. dynamodb-local) reachable at
+// DYNAMODB_ENDPOINT.
+func TestPutFindOneRoundTrip(t *testing.T) {
+	endpoint := os.Getenv("DYNAMODB_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("DYNAMODB_ENDPOINT not set; skipping integration test")
+	}
+
+	tableName := "movies-integration-test"
+	os.Setenv("TABLE_NAME", tableName)
+	os.Setenv("AWS_REGION", "us-east-1")
+
+	svc := dynamodb.New(session.Must(session.NewSession(&aws.Config{
+		Endpoint: aws.String(endpoint),
+		Region:   aws.String("us-east-1"),
+	})))
+
+	if _, err := svc.CreateTable(&dynamodb.CreateTableInput{
+		TableName: aws.String(tableName),
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{AttributeName: aws.String("ID"), AttributeType: aws.String("S")},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{AttributeName: aws.String("ID"), KeyType: aws.String("HASH")},
+		},
+		BillingMode: aws.String("PAY_PER_REQUEST"),
+	}); err != nil {
+		t.Fatalf("creating test table: %v", err)
+	}
+	defer svc.DeleteTable(&dynamodb.DeleteTableInput{TableName: aws.String(tableName)})
+
+	repo := movies.NewRepository()
+
+	want := movies.Movie{ID: "tt0000001", Title: "Arrival", ReleaseYear: 2016, Tags: []string{"sci-fi"}}
+	if err := repo.Put(want); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, err := repo.FindOne(want.ID)
+	if err != nil {
+		t.Fatalf("FindOne: %v", err)
+	}
+	if got.ID != want.ID || got.Title != want.Title || got.ReleaseYear != want.ReleaseYear {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+
+	if err := repo.Delete(want.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindOne(want.ID); err != movies.ErrNotFound {
+		t.Fatalf("FindOne after Delete: got err %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,48 @@
+// Package movies provides the persistence layer shared by every Lambda
+// entrypoint that reads or writes the Movies table, so adding a new
+// operation no longer means copy-pasting a whole file.
+package movies
+
+import "time"
+
+// nameIndexBucket is the constant NameIndex partition key, letting the
+// search Lambda run a begins_with Query across every movie instead of a
+// table Scan.
+const nameIndexBucket = "MOVIE"
+
+// Movie is the persisted shape of a movie record. dynamodbav tags are
+// explicit and spelled out in full: dynamodbattribute.MarshalMap falls back
+// to the json tag (lowercase, snake_case) when one isn't set, which would
+// silently disagree with the "ID"/"Bucket"-style attribute names FindOne,
+// Delete, and every hand-built expression.Name/expression.Key in this repo
+// already read and write by.
+type Movie struct {
+	ID          string    `json:"id" dynamodbav:"ID"`
+	Title       string    `json:"title" dynamodbav:"Title"`
+	IMDbID      string    `json:"imdb_id,omitempty" dynamodbav:"IMDbID,omitempty"`
+	ReleaseYear int       `json:"release_year,omitempty" dynamodbav:"ReleaseYear,omitempty"`
+	Tags        []string  `json:"tags,omitempty" dynamodbav:"Tags,omitempty"`
+	Rating      float64   `json:"rating,omitempty" dynamodbav:"Rating,omitempty"`
+	PosterURL   string    `json:"poster_url,omitempty" dynamodbav:"PosterURL,omitempty"`
+	Synopsis    string    `json:"synopsis,omitempty" dynamodbav:"Synopsis,omitempty"`
+	MagnetLinks []string  `json:"magnet_links,omitempty" dynamodbav:"MagnetLinks,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty" dynamodbav:"UpdatedAt,omitempty"`
+	Bucket      string    `json:"-" dynamodbav:"Bucket"`
+}
+
+// ErrNotFound is returned by FindOne when no movie exists with the given ID.
+var ErrNotFound = notFoundError{}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string { return "movie not found" }
+
+// Repository is everything a Lambda handler needs to do with the Movies
+// table.
+type Repository interface {
+	FindOne(id string) (Movie, error)
+	FindAll() ([]Movie, error)
+	Put(movie Movie) error
+	Update(movie Movie) error
+	Delete(id string) error
+}
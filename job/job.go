@@ -0,0 +1,86 @@
+// Package job defines the shared Job model and the handler registry used by
+// the worker Lambda to execute background movie-enrichment work.
+package job
+
+import "time"
+
+// Status is the lifecycle state of a Job.
+type Status string
+
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Type identifies which Handler should process a Job.
+type Type string
+
+const (
+	// TypeFetchMetadata pulls poster/synopsis data from an external source.
+	TypeFetchMetadata Type = "fetch_metadata"
+	// TypeTranscodeArtwork re-encodes a movie's poster/artwork assets.
+	TypeTranscodeArtwork Type = "transcode_artwork"
+	// TypeReindexSearch refreshes the search index entry for a movie.
+	TypeReindexSearch Type = "reindex_search"
+)
+
+// MaxAttempts bounds how many times a job is retried before it is left in
+// StatusFailed for good.
+const MaxAttempts = 5
+
+// VisibilityTimeout is how long a worker holds a lease on a job before it is
+// considered crashed and becomes eligible to be picked up again.
+const VisibilityTimeout = 2 * time.Minute
+
+// Job is the persisted record in the Jobs table.
+type Job struct {
+	ID          string    `json:"id"`
+	MovieID     string    `json:"movie_id"`
+	Type        Type      `json:"type"`
+	Status      Status    `json:"status"`
+	Payload     string    `json:"payload,omitempty"`
+	Result      string    `json:"result,omitempty"`
+	Attempts    int       `json:"attempts"`
+	LeaseOwner  string    `json:"lease_owner,omitempty"`
+	LeaseExpiry time.Time `json:"lease_expiry,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Leased reports whether the job is currently held by a worker whose
+// visibility timeout has not yet elapsed.
+func (j Job) Leased(now time.Time) bool {
+	return j.LeaseOwner != "" && now.Before(j.LeaseExpiry)
+}
+
+// Backoff returns how long to wait before the next attempt, growing
+// exponentially with the number of attempts already made.
+func Backoff(attempts int) time.Duration {
+	d := time.Duration(1<<uint(attempts)) * time.Second
+	if max := 5 * time.Minute; d > max {
+		return max
+	}
+	return d
+}
+
+// Handler executes a single job and returns the result payload to persist,
+// or an error if the job should be retried.
+type Handler func(j Job) (result string, err error)
+
+// registry maps a Type to the Handler that processes it.
+var registry = map[Type]Handler{}
+
+// Register adds a Handler for the given Type. Intended to be called from
+// worker init() functions so new job types can be added without touching the
+// dispatch loop.
+func Register(t Type, h Handler) {
+	registry[t] = h
+}
+
+// Lookup returns the Handler registered for t, if any.
+func Lookup(t Type) (Handler, bool) {
+	h, ok := registry[t]
+	return h, ok
+}
@@ -0,0 +1,88 @@
+// Package paginator bridges DynamoDB's native ExclusiveStartKey /
+// LastEvaluatedKey cursors with opaque page tokens returned to API clients,
+// and keeps the legacy ?page= API working by caching the cursor seen at the
+// end of each page.
+//
+// That page cache is process-local (a single Lambda execution environment),
+// not shared across concurrent invocations or instances. A ?page=N request
+// only succeeds if it happens to land on an instance that already cached
+// page N-1's cursor; callers should treat a cache miss as "page unavailable,
+// retry with the cursor from the previous response" rather than assume the
+// legacy API is reliable across instances. New integrations should prefer
+// the opaque ?cursor= token, which has no such caveat.
+package paginator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// Cursor is a DynamoDB pagination key, kept as the same shape DynamoDB
+// expects so it can be passed straight into a QueryInput.
+type Cursor = map[string]*dynamodb.AttributeValue
+
+// Encode turns a DynamoDB LastEvaluatedKey into an opaque token safe to hand
+// back to API clients. An empty key encodes to an empty token.
+func Encode(key Cursor) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	raw, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("encoding cursor: %w", err)
+	}
+
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
+
+// Decode turns a token produced by Encode back into a DynamoDB cursor. An
+// empty token decodes to a nil cursor, i.e. "start from the beginning".
+func Decode(token string) (Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decoding cursor: %w", err)
+	}
+
+	var key Cursor
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return nil, fmt.Errorf("decoding cursor: %w", err)
+	}
+
+	return key, nil
+}
+
+// pageCursors remembers the cursor that follows a given page of a given
+// query, keyed by "<querySignature>:<pageNum>", so a ?page=N request can
+// resume a Query instead of rescanning every page before it.
+var pageCursors sync.Map
+
+// Remember records the cursor to resume from after serving pageNum of the
+// query identified by querySignature.
+func Remember(querySignature string, pageNum int, next Cursor) {
+	pageCursors.Store(fmt.Sprintf("%s:%d", querySignature, pageNum), next)
+}
+
+// Lookup returns the cursor to start pageNum from, if a prior request for
+// this query already reached pageNum-1.
+func Lookup(querySignature string, pageNum int) (Cursor, bool) {
+	if pageNum <= 1 {
+		return nil, true
+	}
+
+	v, ok := pageCursors.Load(fmt.Sprintf("%s:%d", querySignature, pageNum-1))
+	if !ok {
+		return nil, false
+	}
+
+	cursor, _ := v.(Cursor)
+	return cursor, true
+}